@@ -0,0 +1,165 @@
+// sky-categories/pkg/clientlib/categoriesclient/batch.go
+package categoriesclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ProjectCategoryAssociation represents one category's association with a
+// project, including its sort position for drag-and-drop style UIs.
+type ProjectCategoryAssociation struct {
+	CategoryID    uuid.UUID `json:"category_id"`
+	ProjectID     uuid.UUID `json:"project_id"`
+	CategoryOrder int       `json:"category_order"`
+}
+
+// SkillCategoryAssociation represents one category's association with a
+// skill, including its sort position for drag-and-drop style UIs.
+type SkillCategoryAssociation struct {
+	CategoryID    uuid.UUID `json:"category_id"`
+	SkillID       uuid.UUID `json:"skill_id"`
+	CategoryOrder int       `json:"category_order"`
+}
+
+// AssociateCategoriesWithProjectRequest represents the request structure to
+// associate a set of categories with a project in a single round-trip.
+type AssociateCategoriesWithProjectRequest struct {
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+	ProjectID   uuid.UUID   `json:"project_id"`
+}
+
+// AssociateCategoriesWithProjectResponse represents the response structure
+// from the batch project-category association request.
+type AssociateCategoriesWithProjectResponse struct {
+	Associations []ProjectCategoryAssociation `json:"associations"`
+}
+
+// BulkUpdateProjectCategoriesRequest represents the request structure to add
+// and remove project-category associations in a single round-trip.
+type BulkUpdateProjectCategoriesRequest struct {
+	ProjectID uuid.UUID   `json:"project_id"`
+	Add       []uuid.UUID `json:"add,omitempty"`
+	Remove    []uuid.UUID `json:"remove,omitempty"`
+}
+
+// BulkUpdateProjectCategoriesResponse represents the response structure from
+// the bulk project-category update request.
+type BulkUpdateProjectCategoriesResponse struct {
+	Associations []ProjectCategoryAssociation `json:"associations"`
+}
+
+// AssociateCategoriesWithSkillRequest represents the request structure to
+// associate a set of categories with a skill in a single round-trip.
+type AssociateCategoriesWithSkillRequest struct {
+	CategoryIDs []uuid.UUID `json:"category_ids"`
+	SkillID     uuid.UUID   `json:"skill_id"`
+}
+
+// AssociateCategoriesWithSkillResponse represents the response structure
+// from the batch skill-category association request.
+type AssociateCategoriesWithSkillResponse struct {
+	Associations []SkillCategoryAssociation `json:"associations"`
+}
+
+// BulkUpdateSkillCategoriesRequest represents the request structure to add
+// and remove skill-category associations in a single round-trip.
+type BulkUpdateSkillCategoriesRequest struct {
+	SkillID uuid.UUID   `json:"skill_id"`
+	Add     []uuid.UUID `json:"add,omitempty"`
+	Remove  []uuid.UUID `json:"remove,omitempty"`
+}
+
+// BulkUpdateSkillCategoriesResponse represents the response structure from
+// the bulk skill-category update request.
+type BulkUpdateSkillCategoriesResponse struct {
+	Associations []SkillCategoryAssociation `json:"associations"`
+}
+
+// AssociateCategoriesWithProject associates a set of categories with a
+// project in a single round-trip using the categories microservice, and
+// returns the resulting association list with its persisted sort order.
+func (c *Client) AssociateCategoriesWithProject(categoryIDs []uuid.UUID, projectID uuid.UUID, authToken string) (AssociateCategoriesWithProjectResponse, error) {
+	return c.AssociateCategoriesWithProjectContext(context.Background(), categoryIDs, projectID, authToken)
+}
+
+// AssociateCategoriesWithProjectContext is the context-aware, retrying
+// equivalent of AssociateCategoriesWithProject.
+func (c *Client) AssociateCategoriesWithProjectContext(ctx context.Context, categoryIDs []uuid.UUID, projectID uuid.UUID, authToken string) (AssociateCategoriesWithProjectResponse, error) {
+	requestBody := AssociateCategoriesWithProjectRequest{
+		CategoryIDs: categoryIDs,
+		ProjectID:   projectID,
+	}
+	var response AssociateCategoriesWithProjectResponse
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/projects/categories/batch-associate", authToken, requestBody, &response); err != nil {
+		return AssociateCategoriesWithProjectResponse{}, err
+	}
+	return response, nil
+}
+
+// BulkUpdateProjectCategories atomically adds and removes project-category
+// associations in a single round-trip instead of N calls, returning the
+// resulting association list.
+func (c *Client) BulkUpdateProjectCategories(projectID uuid.UUID, add, remove []uuid.UUID, authToken string) (BulkUpdateProjectCategoriesResponse, error) {
+	return c.BulkUpdateProjectCategoriesContext(context.Background(), projectID, add, remove, authToken)
+}
+
+// BulkUpdateProjectCategoriesContext is the context-aware, retrying
+// equivalent of BulkUpdateProjectCategories.
+func (c *Client) BulkUpdateProjectCategoriesContext(ctx context.Context, projectID uuid.UUID, add, remove []uuid.UUID, authToken string) (BulkUpdateProjectCategoriesResponse, error) {
+	requestBody := BulkUpdateProjectCategoriesRequest{
+		ProjectID: projectID,
+		Add:       add,
+		Remove:    remove,
+	}
+	var response BulkUpdateProjectCategoriesResponse
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/projects/categories/bulk-update", authToken, requestBody, &response); err != nil {
+		return BulkUpdateProjectCategoriesResponse{}, err
+	}
+	return response, nil
+}
+
+// AssociateCategoriesWithSkill associates a set of categories with a skill
+// in a single round-trip using the categories microservice, and returns the
+// resulting association list with its persisted sort order.
+func (c *Client) AssociateCategoriesWithSkill(categoryIDs []uuid.UUID, skillID uuid.UUID, authToken string) (AssociateCategoriesWithSkillResponse, error) {
+	return c.AssociateCategoriesWithSkillContext(context.Background(), categoryIDs, skillID, authToken)
+}
+
+// AssociateCategoriesWithSkillContext is the context-aware, retrying
+// equivalent of AssociateCategoriesWithSkill.
+func (c *Client) AssociateCategoriesWithSkillContext(ctx context.Context, categoryIDs []uuid.UUID, skillID uuid.UUID, authToken string) (AssociateCategoriesWithSkillResponse, error) {
+	requestBody := AssociateCategoriesWithSkillRequest{
+		CategoryIDs: categoryIDs,
+		SkillID:     skillID,
+	}
+	var response AssociateCategoriesWithSkillResponse
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/categories/skills/batch-association", authToken, requestBody, &response); err != nil {
+		return AssociateCategoriesWithSkillResponse{}, err
+	}
+	return response, nil
+}
+
+// BulkUpdateSkillCategories atomically adds and removes skill-category
+// associations in a single round-trip instead of N calls, returning the
+// resulting association list.
+func (c *Client) BulkUpdateSkillCategories(skillID uuid.UUID, add, remove []uuid.UUID, authToken string) (BulkUpdateSkillCategoriesResponse, error) {
+	return c.BulkUpdateSkillCategoriesContext(context.Background(), skillID, add, remove, authToken)
+}
+
+// BulkUpdateSkillCategoriesContext is the context-aware, retrying equivalent
+// of BulkUpdateSkillCategories.
+func (c *Client) BulkUpdateSkillCategoriesContext(ctx context.Context, skillID uuid.UUID, add, remove []uuid.UUID, authToken string) (BulkUpdateSkillCategoriesResponse, error) {
+	requestBody := BulkUpdateSkillCategoriesRequest{
+		SkillID: skillID,
+		Add:     add,
+		Remove:  remove,
+	}
+	var response BulkUpdateSkillCategoriesResponse
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/categories/skills/bulk-update", authToken, requestBody, &response); err != nil {
+		return BulkUpdateSkillCategoriesResponse{}, err
+	}
+	return response, nil
+}
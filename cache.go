@@ -0,0 +1,126 @@
+// sky-categories/pkg/clientlib/categoriesclient/cache.go
+package categoriesclient
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CacheEntry is what a Cache stores for a single cached GET response: the
+// server's ETag, the raw (undecoded) response body so a later 304 can be
+// re-decoded without another round-trip, and the original response headers
+// (e.g. X-Total-Count, Link) so pagination metadata survives a 304, which
+// carries none of its own.
+type CacheEntry struct {
+	ETag     string
+	Body     []byte
+	Header   http.Header
+	StoredAt time.Time
+}
+
+// Cache is the pluggable store doRequest consults for conditional GETs. Keys
+// combine the caller's auth token with the full request URL (see
+// cacheKeyFor). Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+}
+
+// requestOptions carries per-call overrides applied by RequestOption.
+type requestOptions struct {
+	skipCache bool
+	headers   map[string]string
+}
+
+// RequestOption customizes a single call to one of the Client's *Context
+// methods.
+type RequestOption func(*requestOptions)
+
+// SkipCache disables the Client's Cache for a single call, forcing a fresh
+// request regardless of any cached ETag.
+func SkipCache() RequestOption {
+	return func(o *requestOptions) {
+		o.skipCache = true
+	}
+}
+
+// WithHeader sets an additional header on a single call, e.g. If-Match for
+// optimistic-concurrency writes.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// TTLCache is the default Cache implementation: an LRU of bounded size whose
+// entries also expire after TTL.
+type TTLCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	items      map[string]*list.Element
+	order      *list.List
+}
+
+type ttlCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewTTLCache creates a Cache that holds at most maxEntries responses, each
+// valid for ttl before it is treated as a miss.
+func NewTTLCache(maxEntries int, ttl time.Duration) *TTLCache {
+	return &TTLCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		items:      make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (c *TTLCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+
+	item := elem.Value.(*ttlCacheItem)
+	if c.ttl > 0 && time.Since(item.entry.StoredAt) > c.ttl {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return CacheEntry{}, false
+	}
+
+	c.order.MoveToFront(elem)
+	return item.entry, true
+}
+
+func (c *TTLCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*ttlCacheItem).entry = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&ttlCacheItem{key: key, entry: entry})
+	c.items[key] = elem
+
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*ttlCacheItem).key)
+		}
+	}
+}
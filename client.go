@@ -2,13 +2,13 @@
 package categoriesclient
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/time/rate"
 )
 
 // Client represents an HTTP client that can be used to send requests to the categories server.
@@ -17,15 +17,29 @@ type Client struct {
 	HttpClient *http.Client
 	Token      string
 	ApiKey     string
+
+	// RetryPolicy controls backoff for retriable status codes. The zero
+	// value means DefaultRetryPolicy is used.
+	RetryPolicy RetryPolicy
+	// RateLimiter, when set, is waited on before every outgoing request.
+	RateLimiter *rate.Limiter
+	// Transport, when set, overrides HttpClient's RoundTripper for requests
+	// made through this Client, e.g. to inject tracing or metrics.
+	Transport http.RoundTripper
+	// Cache, when set, is consulted on GET requests to send conditional
+	// If-None-Match requests and reuse the previous value on a 304. Nil
+	// disables caching entirely.
+	Cache Cache
 }
 
 // Category represents the structure of a category.
 type Category struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Version   int       `json:"version"`
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name,omitempty"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Version   int        `json:"version"`
 }
 
 // CreateCategoryRequest represents the structure of a category for a create request.
@@ -91,387 +105,170 @@ func NewClient(baseURL string, token string, apiKey string, httpClient ...*http.
 
 // CreateCategory creates a new category using the categories microservice.
 func (c *Client) CreateCategory(cat *CreateCategoryRequest, authToken string) (*Category, error) {
-	// Marshal the Category struct into a JSON string.
-	reqBody, err := json.Marshal(cat)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode category into JSON: %w", err)
-	}
-
-	// Build the request.
-	url := fmt.Sprintf("%s/api/categories", c.BaseURL)
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusCreated {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return nil, fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
+	return c.CreateCategoryContext(context.Background(), cat, authToken)
+}
 
-	// Decode the response body.
+// CreateCategoryContext is the context-aware, retrying equivalent of
+// CreateCategory.
+func (c *Client) CreateCategoryContext(ctx context.Context, cat *CreateCategoryRequest, authToken string) (*Category, error) {
 	var createdCat Category
-	if err := json.NewDecoder(resp.Body).Decode(&createdCat); err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/categories", authToken, cat, &createdCat); err != nil {
+		return nil, err
 	}
-
 	return &createdCat, nil
 }
 
 // GetCategory retrieves a category using the categories microservice.
 func (c *Client) GetCategory(categoryID uuid.UUID, authToken string) (*Category, error) {
-	// Build the request.
-	url := fmt.Sprintf("%s/api/categories/%s", c.BaseURL, categoryID.String())
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusOK {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return nil, fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
+	cat, _, err := c.GetCategoryContext(context.Background(), categoryID, authToken)
+	return cat, err
+}
 
-	// Decode the response body.
+// GetCategoryContext is the context-aware, retrying equivalent of
+// GetCategory. The returned *Response exposes the ETag and RequestID of the
+// call, whether served fresh or reconstructed from a 304 cache hit.
+func (c *Client) GetCategoryContext(ctx context.Context, categoryID uuid.UUID, authToken string, opts ...RequestOption) (*Category, *Response, error) {
+	path := fmt.Sprintf("/api/categories/%s", categoryID.String())
 	var retrievedCategory Category
-	if err := json.NewDecoder(resp.Body).Decode(&retrievedCategory); err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &retrievedCategory, opts...)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	return &retrievedCategory, nil
+	return &retrievedCategory, resp, nil
 }
 
 // AssociateCategoryWithProject associates a category with a project using the categories microservice.
 func (c *Client) AssociateCategoryWithProject(categoryID, projectID uuid.UUID, authToken string) error {
-	// Build the request body.
+	return c.AssociateCategoryWithProjectContext(context.Background(), categoryID, projectID, authToken)
+}
+
+// AssociateCategoryWithProjectContext is the context-aware, retrying
+// equivalent of AssociateCategoryWithProject.
+func (c *Client) AssociateCategoryWithProjectContext(ctx context.Context, categoryID, projectID uuid.UUID, authToken string) error {
 	requestBody := AssociateCategoryWithProjectRequest{
 		CategoryID: categoryID,
 		ProjectID:  projectID,
 	}
-	requestBodyBytes, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("failed to encode request body: %w", err)
-	}
-
-	// Build the request.
-	url := fmt.Sprintf("%s/api/projects/categories/associate", c.BaseURL)
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(requestBodyBytes))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusCreated {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
-
-	return nil
+	_, err := c.doRequest(ctx, http.MethodPost, "/api/projects/categories/associate", authToken, requestBody, nil)
+	return err
 }
 
 // DisassociateCategoryFromProject disassociates a category from a project using the categories microservice.
 func (c *Client) DisassociateCategoryFromProject(categoryID, projectID uuid.UUID, authToken string) error {
-	// Build the request body.
+	return c.DisassociateCategoryFromProjectContext(context.Background(), categoryID, projectID, authToken)
+}
+
+// DisassociateCategoryFromProjectContext is the context-aware, retrying
+// equivalent of DisassociateCategoryFromProject.
+func (c *Client) DisassociateCategoryFromProjectContext(ctx context.Context, categoryID, projectID uuid.UUID, authToken string) error {
 	requestBody := AssociateCategoryWithProjectRequest{
 		CategoryID: categoryID,
 		ProjectID:  projectID,
 	}
-	requestBodyBytes, err := json.Marshal(requestBody)
-	if err != nil {
-		return fmt.Errorf("failed to encode request body: %w", err)
-	}
-
-	// Build the request.
-	url := fmt.Sprintf("%s/api/projects/categories/disassociate", c.BaseURL)
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(requestBodyBytes))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusNoContent {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
-
-	return nil
+	_, err := c.doRequest(ctx, http.MethodPost, "/api/projects/categories/disassociate", authToken, requestBody, nil)
+	return err
 }
 
 // GetCategoriesForProject gets the categories associated with a specific project using the categories microservice.
 func (c *Client) GetCategoriesForProject(projectID uuid.UUID, authToken string) ([]Category, error) {
-	// Build the request.
-	url := fmt.Sprintf("%s/api/projects/%s/categories", c.BaseURL, projectID)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusOK {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return nil, fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
+	categories, _, err := c.GetCategoriesForProjectContext(context.Background(), projectID, authToken)
+	return categories, err
+}
 
-	// Decode the response body.
+// GetCategoriesForProjectContext is the context-aware, retrying equivalent
+// of GetCategoriesForProject. The returned *Response exposes the ETag and
+// RequestID of the call, whether served fresh or reconstructed from a 304
+// cache hit.
+func (c *Client) GetCategoriesForProjectContext(ctx context.Context, projectID uuid.UUID, authToken string, opts ...RequestOption) ([]Category, *Response, error) {
+	path := fmt.Sprintf("/api/projects/%s/categories", projectID)
 	var categories []Category
-	err = json.NewDecoder(resp.Body).Decode(&categories)
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &categories, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
+		return nil, nil, err
 	}
-
-	return categories, nil
+	return categories, resp, nil
 }
 
 // GetProjectIDsForCategory gets the project IDs associated with a specific category using the categories microservice.
 func (c *Client) GetProjectIDsForCategory(categoryID uuid.UUID, authToken string) ([]uuid.UUID, error) {
-	// Build the request.
-	url := fmt.Sprintf("%s/api/categories/%s/projects", c.BaseURL, categoryID)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusOK {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return nil, fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
+	projectIDs, _, err := c.GetProjectIDsForCategoryContext(context.Background(), categoryID, authToken)
+	return projectIDs, err
+}
 
-	// Decode the response body.
+// GetProjectIDsForCategoryContext is the context-aware, retrying equivalent
+// of GetProjectIDsForCategory. The returned *Response exposes the ETag and
+// RequestID of the call, whether served fresh or reconstructed from a 304
+// cache hit.
+func (c *Client) GetProjectIDsForCategoryContext(ctx context.Context, categoryID uuid.UUID, authToken string, opts ...RequestOption) ([]uuid.UUID, *Response, error) {
+	path := fmt.Sprintf("/api/categories/%s/projects", categoryID)
 	var projectIDs []uuid.UUID
-	err = json.NewDecoder(resp.Body).Decode(&projectIDs)
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &projectIDs, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
+		return nil, nil, err
 	}
-
-	return projectIDs, nil
+	return projectIDs, resp, nil
 }
 
 // AssociateCategoryWithSkill associates a category with a skill using the categories microservice.
 func (c *Client) AssociateCategoryWithSkill(request AssociateCategoryWithSkillRequest, authToken string) error {
-	// Marshal the request into JSON.
-	reqBody, err := json.Marshal(request)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Build the request.
-	url := fmt.Sprintf("%s/api/categories/skills/association", c.BaseURL)
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusCreated {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
+	return c.AssociateCategoryWithSkillContext(context.Background(), request, authToken)
+}
 
-	return nil
+// AssociateCategoryWithSkillContext is the context-aware, retrying
+// equivalent of AssociateCategoryWithSkill.
+func (c *Client) AssociateCategoryWithSkillContext(ctx context.Context, request AssociateCategoryWithSkillRequest, authToken string) error {
+	_, err := c.doRequest(ctx, http.MethodPost, "/api/categories/skills/association", authToken, request, nil)
+	return err
 }
 
 // DisassociateCategoryFromSkill disassociates a category from a skill using the categories microservice.
 func (c *Client) DisassociateCategoryFromSkill(request DisassociateCategoryFromSkillRequest, authToken string) error {
-	// Marshal the request into JSON.
-	reqBody, err := json.Marshal(request)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Build the request.
-	url := fmt.Sprintf("%s/api/categories/skills/disassociation", c.BaseURL)
-	req, err := http.NewRequest(http.MethodDelete, url, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusNoContent {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
+	return c.DisassociateCategoryFromSkillContext(context.Background(), request, authToken)
+}
 
-	return nil
+// DisassociateCategoryFromSkillContext is the context-aware, retrying
+// equivalent of DisassociateCategoryFromSkill.
+func (c *Client) DisassociateCategoryFromSkillContext(ctx context.Context, request DisassociateCategoryFromSkillRequest, authToken string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, "/api/categories/skills/disassociation", authToken, request, nil)
+	return err
 }
 
 // GetCategoriesForSkill retrieves the categories associated with a specific skill.
 func (c *Client) GetCategoriesForSkill(request GetCategoriesForSkillRequest, authToken string) (GetCategoriesForSkillResponse, error) {
-	// Prepare the URL for the request.
-	url := fmt.Sprintf("%s/api/skills/%s/categories", c.BaseURL, request.SkillID)
-
-	// Create the HTTP request.
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return GetCategoriesForSkillResponse{}, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return GetCategoriesForSkillResponse{}, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusOK {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return GetCategoriesForSkillResponse{}, fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
+	response, _, err := c.GetCategoriesForSkillContext(context.Background(), request, authToken)
+	return response, err
+}
 
-	// Decode the response.
+// GetCategoriesForSkillContext is the context-aware, retrying equivalent of
+// GetCategoriesForSkill. The returned *Response exposes the ETag and
+// RequestID of the call, whether served fresh or reconstructed from a 304
+// cache hit.
+func (c *Client) GetCategoriesForSkillContext(ctx context.Context, request GetCategoriesForSkillRequest, authToken string, opts ...RequestOption) (GetCategoriesForSkillResponse, *Response, error) {
+	path := fmt.Sprintf("/api/skills/%s/categories", request.SkillID)
 	var response GetCategoriesForSkillResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &response, opts...)
 	if err != nil {
-		return GetCategoriesForSkillResponse{}, fmt.Errorf("failed to decode response: %w", err)
+		return GetCategoriesForSkillResponse{}, nil, err
 	}
-
-	return response, nil
+	return response, resp, nil
 }
 
 // GetSkillIDsForCategory retrieves the skill IDs associated with a specific category.
 func (c *Client) GetSkillIDsForCategory(request GetSkillIDsForCategoryRequest, authToken string) (GetSkillIDsForCategoryResponse, error) {
-	// Prepare the URL for the request.
-	url := fmt.Sprintf("%s/api/categories/%s/skills", c.BaseURL, request.CategoryID)
-
-	// Create the HTTP request.
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return GetSkillIDsForCategoryResponse{}, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set the request headers.
-	req.Header.Set("Authorization", authToken)
-	req.Header.Set("X-API-Key", c.ApiKey)
-
-	// Send the request.
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return GetSkillIDsForCategoryResponse{}, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check the status code of the response.
-	if resp.StatusCode != http.StatusOK {
-		// For simplicity, we just return an error here.
-		// In a real-world application, you'd likely want to return a more detailed error message.
-		return GetSkillIDsForCategoryResponse{}, fmt.Errorf("unexpected status code: got %v", resp.StatusCode)
-	}
+	response, _, err := c.GetSkillIDsForCategoryContext(context.Background(), request, authToken)
+	return response, err
+}
 
-	// Decode the response.
+// GetSkillIDsForCategoryContext is the context-aware, retrying equivalent of
+// GetSkillIDsForCategory. The returned *Response exposes the ETag and
+// RequestID of the call, whether served fresh or reconstructed from a 304
+// cache hit.
+func (c *Client) GetSkillIDsForCategoryContext(ctx context.Context, request GetSkillIDsForCategoryRequest, authToken string, opts ...RequestOption) (GetSkillIDsForCategoryResponse, *Response, error) {
+	path := fmt.Sprintf("/api/categories/%s/skills", request.CategoryID)
 	var response GetSkillIDsForCategoryResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &response, opts...)
 	if err != nil {
-		return GetSkillIDsForCategoryResponse{}, fmt.Errorf("failed to decode response: %w", err)
+		return GetSkillIDsForCategoryResponse{}, nil, err
 	}
-
-	return response, nil
+	return response, resp, nil
 }
@@ -0,0 +1,85 @@
+// sky-categories/pkg/clientlib/categoriesclient/errors.go
+package categoriesclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Response carries the metadata of an HTTP response returned by the
+// categories microservice, independent of whether the call succeeded or
+// failed.
+type Response struct {
+	StatusCode int
+	RequestID  string
+	Header     http.Header
+	// ETag is the server's ETag for the resource, when present, whether the
+	// response was served fresh or reconstructed from a 304-confirmed Cache
+	// entry.
+	ETag string
+}
+
+// errorEnvelope is the JSON shape the categories microservice uses to report
+// errors, e.g. {"id": "category_not_found", "message": "category does not exist"}.
+type errorEnvelope struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// APIError is returned whenever the categories microservice responds with a
+// non-success status code. It captures the response metadata and, when
+// present, the server's decoded error envelope, so callers can branch on the
+// failure (var apiErr *categoriesclient.APIError; errors.As(err, &apiErr))
+// instead of matching on error strings.
+type APIError struct {
+	Response
+	ServerErrorID string
+	Message       string
+	Body          []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("categoriesclient: %s (status %d, request %s)", e.Message, e.StatusCode, e.RequestID)
+	}
+	return fmt.Sprintf("categoriesclient: unexpected status code: got %d (request %s)", e.StatusCode, e.RequestID)
+}
+
+// IsClientError reports whether the categories microservice rejected the
+// request (4xx). Apart from 429 Too Many Requests, which doRequest retries,
+// a retry with the same payload is not expected to help.
+func (e *APIError) IsClientError() bool {
+	return e.StatusCode >= 400 && e.StatusCode < 500
+}
+
+// IsServerError reports whether the failure originated on the server (5xx),
+// meaning the same request may succeed if retried.
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= 500 && e.StatusCode < 600
+}
+
+// newAPIError builds an *APIError from a non-success HTTP response, decoding
+// the server's JSON error envelope when one is present. It consumes and
+// closes resp.Body; callers must not read from it afterwards.
+func newAPIError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	apiErr := &APIError{
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			RequestID:  resp.Header.Get("X-Request-ID"),
+			Header:     resp.Header,
+		},
+		Body: body,
+	}
+
+	var envelope errorEnvelope
+	if len(body) > 0 && json.Unmarshal(body, &envelope) == nil {
+		apiErr.ServerErrorID = envelope.ID
+		apiErr.Message = envelope.Message
+	}
+
+	return apiErr
+}
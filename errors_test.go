@@ -0,0 +1,86 @@
+// sky-categories/pkg/clientlib/categoriesclient/errors_test.go
+package categoriesclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	body := []byte(`{"id":"category_not_found","message":"category does not exist"}`)
+
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set("X-Request-ID", "req-123")
+	recorder.WriteHeader(http.StatusNotFound)
+	recorder.Write(body)
+	resp := recorder.Result()
+
+	err := newAPIError(resp)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("errors.As(err, &apiErr) = false, want true (got %T)", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusNotFound)
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("RequestID = %q, want %q", apiErr.RequestID, "req-123")
+	}
+	if apiErr.ServerErrorID != "category_not_found" {
+		t.Errorf("ServerErrorID = %q, want %q", apiErr.ServerErrorID, "category_not_found")
+	}
+	if apiErr.Message != "category does not exist" {
+		t.Errorf("Message = %q, want %q", apiErr.Message, "category does not exist")
+	}
+	if string(apiErr.Body) != string(body) {
+		t.Errorf("Body = %q, want %q", apiErr.Body, body)
+	}
+}
+
+func TestNewAPIError_NonJSONBody(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusBadGateway)
+	recorder.Write([]byte("upstream exploded"))
+	resp := recorder.Result()
+
+	err := newAPIError(resp)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("errors.As(err, &apiErr) = false, want true (got %T)", err)
+	}
+	if apiErr.ServerErrorID != "" || apiErr.Message != "" {
+		t.Errorf("expected no decoded envelope for non-JSON body, got ServerErrorID=%q Message=%q", apiErr.ServerErrorID, apiErr.Message)
+	}
+	if string(apiErr.Body) != "upstream exploded" {
+		t.Errorf("Body = %q, want %q", apiErr.Body, "upstream exploded")
+	}
+}
+
+func TestAPIError_IsClientServerError(t *testing.T) {
+	cases := []struct {
+		status     int
+		wantClient bool
+		wantServer bool
+	}{
+		{399, false, false},
+		{http.StatusBadRequest, true, false},
+		{499, true, false},
+		{http.StatusInternalServerError, false, true},
+		{599, false, true},
+		{600, false, false},
+	}
+
+	for _, tc := range cases {
+		apiErr := &APIError{Response: Response{StatusCode: tc.status}}
+		if got := apiErr.IsClientError(); got != tc.wantClient {
+			t.Errorf("status %d: IsClientError() = %v, want %v", tc.status, got, tc.wantClient)
+		}
+		if got := apiErr.IsServerError(); got != tc.wantServer {
+			t.Errorf("status %d: IsServerError() = %v, want %v", tc.status, got, tc.wantServer)
+		}
+	}
+}
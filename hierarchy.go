@@ -0,0 +1,142 @@
+// sky-categories/pkg/clientlib/categoriesclient/hierarchy.go
+package categoriesclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ErrCategoryCycle is returned by MoveCategory when moving a category under
+// the proposed new parent would make the category its own ancestor. It is
+// detected client-side by fetching and walking the new parent's ancestors,
+// so callers get a clear error before the move request is ever sent.
+var ErrCategoryCycle = errors.New("categoriesclient: moving category would create a cycle")
+
+// CategoryNode is a single node in a category tree returned by
+// GetCategoryTree.
+type CategoryNode struct {
+	Category
+	Children []*CategoryNode `json:"children,omitempty"`
+}
+
+// CreateSubcategoryRequest represents the request structure to create a
+// category underneath an existing parent category.
+type CreateSubcategoryRequest struct {
+	Name     string    `json:"name,omitempty"`
+	ParentID uuid.UUID `json:"parent_id"`
+}
+
+// MoveCategoryRequest represents the request structure to reparent an
+// existing category.
+type MoveCategoryRequest struct {
+	CategoryID  uuid.UUID `json:"category_id"`
+	NewParentID uuid.UUID `json:"new_parent_id"`
+}
+
+// CreateSubcategory creates a new category as a child of parentID using the
+// categories microservice.
+func (c *Client) CreateSubcategory(parentID uuid.UUID, cat *CreateCategoryRequest, authToken string) (*Category, error) {
+	return c.CreateSubcategoryContext(context.Background(), parentID, cat, authToken)
+}
+
+// CreateSubcategoryContext is the context-aware, retrying equivalent of
+// CreateSubcategory.
+func (c *Client) CreateSubcategoryContext(ctx context.Context, parentID uuid.UUID, cat *CreateCategoryRequest, authToken string) (*Category, error) {
+	requestBody := CreateSubcategoryRequest{
+		Name:     cat.Name,
+		ParentID: parentID,
+	}
+	var created Category
+	if _, err := c.doRequest(ctx, http.MethodPost, "/api/categories/subcategories", authToken, requestBody, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// MoveCategory reparents categoryID under newParentID using the categories
+// microservice. Before issuing the request, it walks the ancestors of
+// newParentID and rejects the move with ErrCategoryCycle if categoryID would
+// become its own ancestor.
+func (c *Client) MoveCategory(categoryID, newParentID uuid.UUID, authToken string) error {
+	return c.MoveCategoryContext(context.Background(), categoryID, newParentID, authToken)
+}
+
+// MoveCategoryContext is the context-aware, retrying equivalent of
+// MoveCategory.
+func (c *Client) MoveCategoryContext(ctx context.Context, categoryID, newParentID uuid.UUID, authToken string) error {
+	if categoryID == newParentID {
+		return ErrCategoryCycle
+	}
+
+	ancestors, err := c.GetCategoryAncestorsContext(ctx, newParentID, authToken)
+	if err != nil {
+		return err
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == categoryID {
+			return ErrCategoryCycle
+		}
+	}
+
+	requestBody := MoveCategoryRequest{
+		CategoryID:  categoryID,
+		NewParentID: newParentID,
+	}
+	_, err = c.doRequest(ctx, http.MethodPost, "/api/categories/move", authToken, requestBody, nil)
+	return err
+}
+
+// GetCategoryTree retrieves the category rooted at rootID along with all of
+// its descendants, nested as a tree.
+func (c *Client) GetCategoryTree(rootID uuid.UUID, authToken string) (*CategoryNode, error) {
+	return c.GetCategoryTreeContext(context.Background(), rootID, authToken)
+}
+
+// GetCategoryTreeContext is the context-aware, retrying equivalent of
+// GetCategoryTree.
+func (c *Client) GetCategoryTreeContext(ctx context.Context, rootID uuid.UUID, authToken string) (*CategoryNode, error) {
+	path := fmt.Sprintf("/api/categories/%s/tree", rootID)
+	var root CategoryNode
+	if _, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &root); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
+
+// GetCategoryAncestors retrieves the chain of parent categories above id,
+// ordered from immediate parent to root.
+func (c *Client) GetCategoryAncestors(id uuid.UUID, authToken string) ([]Category, error) {
+	return c.GetCategoryAncestorsContext(context.Background(), id, authToken)
+}
+
+// GetCategoryAncestorsContext is the context-aware, retrying equivalent of
+// GetCategoryAncestors.
+func (c *Client) GetCategoryAncestorsContext(ctx context.Context, id uuid.UUID, authToken string) ([]Category, error) {
+	path := fmt.Sprintf("/api/categories/%s/ancestors", id)
+	var ancestors []Category
+	if _, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &ancestors); err != nil {
+		return nil, err
+	}
+	return ancestors, nil
+}
+
+// GetCategoryDescendants retrieves every category beneath id in the
+// taxonomy, in server-determined order.
+func (c *Client) GetCategoryDescendants(id uuid.UUID, authToken string) ([]Category, error) {
+	return c.GetCategoryDescendantsContext(context.Background(), id, authToken)
+}
+
+// GetCategoryDescendantsContext is the context-aware, retrying equivalent of
+// GetCategoryDescendants.
+func (c *Client) GetCategoryDescendantsContext(ctx context.Context, id uuid.UUID, authToken string) ([]Category, error) {
+	path := fmt.Sprintf("/api/categories/%s/descendants", id)
+	var descendants []Category
+	if _, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &descendants); err != nil {
+		return nil, err
+	}
+	return descendants, nil
+}
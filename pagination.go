@@ -0,0 +1,200 @@
+// sky-categories/pkg/clientlib/categoriesclient/pagination.go
+package categoriesclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListOptions carries pagination, filtering, and sorting parameters for the
+// categories microservice's list endpoints. Zero-valued fields are omitted
+// from the request.
+type ListOptions struct {
+	Page         int
+	PerPage      int
+	Query        string
+	SortBy       string
+	SortDir      string
+	UpdatedSince time.Time
+}
+
+// queryString encodes o as a URL query string, e.g. "?page=2&per_page=20",
+// or "" if every field is at its zero value.
+func (o ListOptions) queryString() string {
+	values := url.Values{}
+	if o.Page > 0 {
+		values.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PerPage > 0 {
+		values.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Query != "" {
+		values.Set("query", o.Query)
+	}
+	if o.SortBy != "" {
+		values.Set("sort_by", o.SortBy)
+	}
+	if o.SortDir != "" {
+		values.Set("sort_dir", o.SortDir)
+	}
+	if !o.UpdatedSince.IsZero() {
+		values.Set("updated_since", o.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
+}
+
+// Page is a single page of a list endpoint's results, along with enough
+// metadata to fetch the next one.
+type Page[T any] struct {
+	Items      []T
+	TotalCount int
+	NextCursor string
+	HasMore    bool
+}
+
+func newPage[T any](items []T, resp *Response) Page[T] {
+	page := Page[T]{Items: items}
+	if resp == nil {
+		return page
+	}
+	page.TotalCount = parseTotalCount(resp.Header)
+	page.NextCursor = parseNextLink(resp.Header)
+	page.HasMore = page.NextCursor != ""
+	return page
+}
+
+func parseTotalCount(header http.Header) int {
+	v := header.Get("X-Total-Count")
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// parseNextLink extracts the rel="next" URL from an RFC 5988 Link header,
+// e.g. `<https://.../categories?page=3>; rel="next"`.
+func parseNextLink(header http.Header) string {
+	link := header.Get("Link")
+	if link == "" {
+		return ""
+	}
+	for _, part := range strings.Split(link, ",") {
+		segments := strings.Split(strings.TrimSpace(part), ";")
+		if len(segments) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(segments[0])
+		target = strings.TrimPrefix(target, "<")
+		target = strings.TrimSuffix(target, ">")
+		for _, attr := range segments[1:] {
+			if strings.TrimSpace(attr) == `rel="next"` {
+				return target
+			}
+		}
+	}
+	return ""
+}
+
+// ListCategories lists categories known to the categories microservice,
+// applying the given pagination, filtering, and sorting options.
+func (c *Client) ListCategories(opts ListOptions, authToken string) (Page[Category], error) {
+	return c.ListCategoriesContext(context.Background(), opts, authToken)
+}
+
+// ListCategoriesContext is the context-aware, retrying equivalent of
+// ListCategories.
+func (c *Client) ListCategoriesContext(ctx context.Context, opts ListOptions, authToken string) (Page[Category], error) {
+	path := "/api/categories" + opts.queryString()
+	var categories []Category
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &categories)
+	if err != nil {
+		return Page[Category]{}, err
+	}
+	return newPage(categories, resp), nil
+}
+
+// ListCategoriesForProject lists the categories associated with a specific
+// project, applying the given pagination, filtering, and sorting options.
+func (c *Client) ListCategoriesForProject(projectID uuid.UUID, opts ListOptions, authToken string) (Page[Category], error) {
+	return c.ListCategoriesForProjectContext(context.Background(), projectID, opts, authToken)
+}
+
+// ListCategoriesForProjectContext is the context-aware, retrying equivalent
+// of ListCategoriesForProject.
+func (c *Client) ListCategoriesForProjectContext(ctx context.Context, projectID uuid.UUID, opts ListOptions, authToken string) (Page[Category], error) {
+	path := fmt.Sprintf("/api/projects/%s/categories%s", projectID, opts.queryString())
+	var categories []Category
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &categories)
+	if err != nil {
+		return Page[Category]{}, err
+	}
+	return newPage(categories, resp), nil
+}
+
+// ListProjectIDsForCategory lists the project IDs associated with a
+// specific category, applying the given pagination and sorting options.
+func (c *Client) ListProjectIDsForCategory(categoryID uuid.UUID, opts ListOptions, authToken string) (Page[uuid.UUID], error) {
+	return c.ListProjectIDsForCategoryContext(context.Background(), categoryID, opts, authToken)
+}
+
+// ListProjectIDsForCategoryContext is the context-aware, retrying
+// equivalent of ListProjectIDsForCategory.
+func (c *Client) ListProjectIDsForCategoryContext(ctx context.Context, categoryID uuid.UUID, opts ListOptions, authToken string) (Page[uuid.UUID], error) {
+	path := fmt.Sprintf("/api/categories/%s/projects%s", categoryID, opts.queryString())
+	var projectIDs []uuid.UUID
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &projectIDs)
+	if err != nil {
+		return Page[uuid.UUID]{}, err
+	}
+	return newPage(projectIDs, resp), nil
+}
+
+// ListCategoriesForSkill lists the categories associated with a specific
+// skill, applying the given pagination, filtering, and sorting options.
+func (c *Client) ListCategoriesForSkill(skillID uuid.UUID, opts ListOptions, authToken string) (Page[Category], error) {
+	return c.ListCategoriesForSkillContext(context.Background(), skillID, opts, authToken)
+}
+
+// ListCategoriesForSkillContext is the context-aware, retrying equivalent of
+// ListCategoriesForSkill.
+func (c *Client) ListCategoriesForSkillContext(ctx context.Context, skillID uuid.UUID, opts ListOptions, authToken string) (Page[Category], error) {
+	path := fmt.Sprintf("/api/skills/%s/categories%s", skillID, opts.queryString())
+	var response GetCategoriesForSkillResponse
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &response)
+	if err != nil {
+		return Page[Category]{}, err
+	}
+	return newPage(response.Categories, resp), nil
+}
+
+// ListSkillIDsForCategory lists the skill IDs associated with a specific
+// category, applying the given pagination and sorting options.
+func (c *Client) ListSkillIDsForCategory(categoryID uuid.UUID, opts ListOptions, authToken string) (Page[uuid.UUID], error) {
+	return c.ListSkillIDsForCategoryContext(context.Background(), categoryID, opts, authToken)
+}
+
+// ListSkillIDsForCategoryContext is the context-aware, retrying equivalent
+// of ListSkillIDsForCategory.
+func (c *Client) ListSkillIDsForCategoryContext(ctx context.Context, categoryID uuid.UUID, opts ListOptions, authToken string) (Page[uuid.UUID], error) {
+	path := fmt.Sprintf("/api/categories/%s/skills%s", categoryID, opts.queryString())
+	var response GetSkillIDsForCategoryResponse
+	resp, err := c.doRequest(ctx, http.MethodGet, path, authToken, nil, &response)
+	if err != nil {
+		return Page[uuid.UUID]{}, err
+	}
+	return newPage(response.SkillIDs, resp), nil
+}
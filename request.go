@@ -0,0 +1,263 @@
+// sky-categories/pkg/clientlib/categoriesclient/request.go
+package categoriesclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RetryPolicy controls how doRequest retries requests that fail with a
+// retriable status code (429, 502, 503, 504). The backoff delay doubles from
+// BaseDelay on each attempt, capped at MaxDelay, and each wait is a random
+// duration between half and all of that delay, to avoid thundering-herd
+// retries against the categories microservice.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// DefaultRetryPolicy is used when a Client does not set RetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  200 * time.Millisecond,
+	MaxDelay:   5 * time.Second,
+}
+
+func isRetriableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay determines how long to wait before the next attempt, honoring
+// the server's Retry-After header (seconds or HTTP-date) when present and
+// otherwise falling back to exponential backoff with jitter.
+func retryDelay(resp *http.Response, attempt int, policy RetryPolicy) time.Duration {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if seconds, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if when, err := http.ParseTime(ra); err == nil {
+			if d := time.Until(when); d > 0 {
+				return d
+			}
+		}
+	}
+
+	delay := policy.BaseDelay << attempt
+	if delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay/2) + 1))
+	return delay/2 + jitter
+}
+
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.RetryPolicy.MaxRetries == 0 && c.RetryPolicy.BaseDelay == 0 {
+		return DefaultRetryPolicy
+	}
+	return c.RetryPolicy
+}
+
+// httpClient returns the *http.Client to use for a request, applying
+// c.Transport as a pluggable RoundTripper hook (e.g. for tracing or metrics)
+// when one is set, without mutating the caller's c.HttpClient.
+func (c *Client) httpClient() *http.Client {
+	hc := c.HttpClient
+	if hc == nil {
+		hc = &http.Client{Timeout: 10 * time.Second}
+	}
+	if c.Transport != nil {
+		clone := *hc
+		clone.Transport = c.Transport
+		hc = &clone
+	}
+	return hc
+}
+
+// doRequest centralizes header injection, JSON marshaling/decoding, rate
+// limiting, and retry/backoff for a single call to the categories
+// microservice. body is marshaled as the JSON request payload when non-nil;
+// out is decoded from the JSON response body when non-nil. It returns the
+// response metadata on success and an *APIError (via errors.As) on any
+// non-2xx response that isn't retried away, except a 304 answered from the
+// Cache, which is returned as a success.
+func (c *Client) doRequest(ctx context.Context, method, path, authToken string, body, out interface{}, opts ...RequestOption) (*Response, error) {
+	var options requestOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var reqBody []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reqBody = b
+	}
+
+	url := fmt.Sprintf("%s%s", c.BaseURL, path)
+	policy := c.retryPolicy()
+	hc := c.httpClient()
+
+	useCache := method == http.MethodGet && c.Cache != nil && !options.skipCache
+	cacheKey := cacheKeyFor(url, authToken)
+	var cached CacheEntry
+	var hasCached bool
+	if useCache {
+		cached, hasCached = c.Cache.Get(cacheKey)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limiter: %w", err)
+			}
+		}
+
+		var bodyReader io.Reader
+		if reqBody != nil {
+			bodyReader = bytes.NewReader(reqBody)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if reqBody != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if authToken != "" {
+			req.Header.Set("Authorization", authToken)
+		}
+		req.Header.Set("X-API-Key", c.ApiKey)
+		if hasCached && cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		for key, value := range options.headers {
+			req.Header.Set(key, value)
+		}
+
+		resp, err := hc.Do(req)
+		if err != nil {
+			if attempt < policy.MaxRetries {
+				if waitErr := sleepContext(ctx, retryDelay(&http.Response{Header: http.Header{}}, attempt, policy)); waitErr != nil {
+					return nil, waitErr
+				}
+				continue
+			}
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		if isRetriableStatus(resp.StatusCode) && attempt < policy.MaxRetries {
+			delay := retryDelay(resp, attempt, policy)
+			resp.Body.Close()
+			if waitErr := sleepContext(ctx, delay); waitErr != nil {
+				return nil, waitErr
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusNotModified && hasCached {
+			resp.Body.Close()
+			// Use the cached entry's headers, not the 304's own (which carry
+			// no X-Total-Count/Link), so pagination metadata survives a cache
+			// hit. X-Request-ID still comes from this response: it identifies
+			// the current request, not the one that populated the cache.
+			header := cached.Header
+			if header == nil {
+				header = resp.Header
+			}
+			respMeta := &Response{
+				StatusCode: http.StatusOK,
+				RequestID:  resp.Header.Get("X-Request-ID"),
+				Header:     header,
+				ETag:       cached.ETag,
+			}
+			if out != nil {
+				if err := json.Unmarshal(cached.Body, out); err != nil {
+					return respMeta, fmt.Errorf("failed to decode cached response body: %w", err)
+				}
+			}
+			return respMeta, nil
+		}
+
+		if resp.StatusCode >= 300 {
+			err := newAPIError(resp)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		respMeta := &Response{
+			StatusCode: resp.StatusCode,
+			RequestID:  resp.Header.Get("X-Request-ID"),
+			Header:     resp.Header,
+			ETag:       resp.Header.Get("ETag"),
+		}
+
+		if useCache && respMeta.ETag != "" {
+			raw, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return respMeta, fmt.Errorf("failed to read response body: %w", err)
+			}
+			if out != nil {
+				if err := json.Unmarshal(raw, out); err != nil {
+					return respMeta, fmt.Errorf("failed to decode response body: %w", err)
+				}
+			}
+			c.Cache.Set(cacheKey, CacheEntry{ETag: respMeta.ETag, Body: raw, Header: resp.Header.Clone(), StoredAt: time.Now()})
+			return respMeta, nil
+		}
+
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				resp.Body.Close()
+				return respMeta, fmt.Errorf("failed to decode response body: %w", err)
+			}
+		}
+		resp.Body.Close()
+		return respMeta, nil
+	}
+}
+
+// cacheKeyFor scopes a Cache entry to both the request URL and the caller's
+// auth identity. Without this, two callers authenticating as different
+// principals but hitting the same URL would be served each other's cached
+// body, and a cached ETag would be replayed as If-None-Match on a request
+// that isn't authorized to see the match.
+func cacheKeyFor(url, authToken string) string {
+	return authToken + "\x00" + url
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewRateLimiter is a convenience constructor for the per-client rate
+// limiter, expressed as a sustained rate (requests per second) and a burst
+// size, matching the shape expected by Client.RateLimiter.
+func NewRateLimiter(requestsPerSecond float64, burst int) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+}
@@ -0,0 +1,220 @@
+// sky-categories/pkg/clientlib/categoriesclient/request_test.go
+package categoriesclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	}
+}
+
+func TestDoRequest_RetriesOnRetriableStatus(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Category{ID: uuid.New(), Name: "widgets"})
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HttpClient: server.Client(), RetryPolicy: fastRetryPolicy()}
+
+	cat, _, err := client.GetCategoryContext(context.Background(), uuid.New(), "token")
+	if err != nil {
+		t.Fatalf("GetCategoryContext returned error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if cat.Name != "widgets" {
+		t.Errorf("Name = %q, want %q", cat.Name, "widgets")
+	}
+}
+
+func TestDoRequest_GivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HttpClient: server.Client(), RetryPolicy: fastRetryPolicy()}
+
+	_, _, err := client.GetCategoryContext(context.Background(), uuid.New(), "token")
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected *APIError with status 503, got %v", err)
+	}
+	if attempts != fastRetryPolicy().MaxRetries+1 {
+		t.Errorf("attempts = %d, want %d", attempts, fastRetryPolicy().MaxRetries+1)
+	}
+}
+
+func TestDoRequest_CacheHitOn304(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Category{ID: uuid.New(), Name: "gadgets"})
+	}))
+	defer server.Close()
+
+	categoryID := uuid.New()
+	client := &Client{BaseURL: server.URL, HttpClient: server.Client(), Cache: NewTTLCache(10, time.Minute)}
+
+	first, firstResp, err := client.GetCategoryContext(context.Background(), categoryID, "token")
+	if err != nil {
+		t.Fatalf("first request returned error: %v", err)
+	}
+
+	second, secondResp, err := client.GetCategoryContext(context.Background(), categoryID, "token")
+	if err != nil {
+		t.Fatalf("second request returned error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 (one miss, one 304)", requests)
+	}
+	if second.Name != first.Name {
+		t.Errorf("cached Name = %q, want %q", second.Name, first.Name)
+	}
+	if firstResp.ETag != `"v1"` {
+		t.Errorf("first Response.ETag = %q, want %q", firstResp.ETag, `"v1"`)
+	}
+	if secondResp.ETag != `"v1"` {
+		t.Errorf("second (304) Response.ETag = %q, want %q", secondResp.ETag, `"v1"`)
+	}
+}
+
+func TestDoRequest_CacheHitOn304PreservesPaginationHeaders(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-Total-Count", "42")
+		w.Header().Set("Link", `<https://example.com/api/categories?page=2>; rel="next"`)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]Category{{ID: uuid.New(), Name: "widgets"}})
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HttpClient: server.Client(), Cache: NewTTLCache(10, time.Minute)}
+
+	first, err := client.ListCategoriesContext(context.Background(), ListOptions{}, "token")
+	if err != nil {
+		t.Fatalf("first request returned error: %v", err)
+	}
+
+	second, err := client.ListCategoriesContext(context.Background(), ListOptions{}, "token")
+	if err != nil {
+		t.Fatalf("second request returned error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 (one miss, one 304)", requests)
+	}
+	if second.TotalCount != first.TotalCount || second.TotalCount != 42 {
+		t.Errorf("second.TotalCount = %d, want %d (first = %d)", second.TotalCount, 42, first.TotalCount)
+	}
+	if !second.HasMore || second.NextCursor != first.NextCursor {
+		t.Errorf("second.HasMore = %v, NextCursor = %q, want HasMore true and NextCursor = %q", second.HasMore, second.NextCursor, first.NextCursor)
+	}
+}
+
+func TestDoRequest_CacheScopedToAuthToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Category{ID: uuid.New(), Name: "caller:" + r.Header.Get("Authorization")})
+	}))
+	defer server.Close()
+
+	categoryID := uuid.New()
+	client := &Client{BaseURL: server.URL, HttpClient: server.Client(), Cache: NewTTLCache(10, time.Minute)}
+
+	forAlice, _, err := client.GetCategoryContext(context.Background(), categoryID, "alice-token")
+	if err != nil {
+		t.Fatalf("alice request returned error: %v", err)
+	}
+	forBob, _, err := client.GetCategoryContext(context.Background(), categoryID, "bob-token")
+	if err != nil {
+		t.Fatalf("bob request returned error: %v", err)
+	}
+
+	if forAlice.Name == forBob.Name {
+		t.Fatalf("expected distinct responses per auth token, got the same cached value %q for both", forAlice.Name)
+	}
+}
+
+func TestDeleteCategoryContext_VersionConflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HttpClient: server.Client()}
+
+	err := client.DeleteCategoryContext(context.Background(), uuid.New(), 1, "token")
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("DeleteCategoryContext error = %v, want ErrVersionConflict", err)
+	}
+}
+
+func TestMoveCategoryContext_RejectsCycle(t *testing.T) {
+	categoryID := uuid.New()
+	newParentID := uuid.New()
+
+	var moveCalled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/categories/" + newParentID.String() + "/ancestors":
+			json.NewEncoder(w).Encode([]Category{{ID: categoryID}})
+		case "/api/categories/move":
+			moveCalled = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, HttpClient: server.Client()}
+
+	err := client.MoveCategoryContext(context.Background(), categoryID, newParentID, "token")
+	if !errors.Is(err, ErrCategoryCycle) {
+		t.Fatalf("MoveCategoryContext error = %v, want ErrCategoryCycle", err)
+	}
+	if moveCalled {
+		t.Error("expected the move endpoint not to be called once a cycle was detected")
+	}
+}
@@ -0,0 +1,92 @@
+// sky-categories/pkg/clientlib/categoriesclient/update.go
+package categoriesclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// ErrVersionConflict is returned by UpdateCategory and DeleteCategory when
+// the server's current version of the category no longer matches the
+// caller's expectedVersion, signaled by an HTTP 409 response.
+var ErrVersionConflict = errors.New("categoriesclient: category version conflict")
+
+// UpdateCategoryRequest represents the structure of a category patch for an
+// update request.
+type UpdateCategoryRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// UpdateCategory applies patch to the category identified by id, sending
+// If-Match to enforce that the server's current version equals
+// expectedVersion. It returns ErrVersionConflict if the server reports a 409
+// due to a version mismatch.
+func (c *Client) UpdateCategory(id uuid.UUID, patch UpdateCategoryRequest, expectedVersion int, authToken string) (*Category, error) {
+	return c.UpdateCategoryContext(context.Background(), id, patch, expectedVersion, authToken)
+}
+
+// UpdateCategoryContext is the context-aware, retrying equivalent of
+// UpdateCategory.
+func (c *Client) UpdateCategoryContext(ctx context.Context, id uuid.UUID, patch UpdateCategoryRequest, expectedVersion int, authToken string) (*Category, error) {
+	path := fmt.Sprintf("/api/categories/%s", id)
+	var updated Category
+	if _, err := c.doRequest(ctx, http.MethodPatch, path, authToken, patch, &updated, withIfMatch(expectedVersion)); err != nil {
+		return nil, versionConflictErr(err)
+	}
+	return &updated, nil
+}
+
+// DeleteCategory soft-deletes the category identified by id, sending
+// If-Match to enforce that the server's current version equals
+// expectedVersion. It returns ErrVersionConflict if the server reports a 409
+// due to a version mismatch.
+func (c *Client) DeleteCategory(id uuid.UUID, expectedVersion int, authToken string) error {
+	return c.DeleteCategoryContext(context.Background(), id, expectedVersion, authToken)
+}
+
+// DeleteCategoryContext is the context-aware, retrying equivalent of
+// DeleteCategory.
+func (c *Client) DeleteCategoryContext(ctx context.Context, id uuid.UUID, expectedVersion int, authToken string) error {
+	path := fmt.Sprintf("/api/categories/%s", id)
+	_, err := c.doRequest(ctx, http.MethodDelete, path, authToken, nil, nil, withIfMatch(expectedVersion))
+	return versionConflictErr(err)
+}
+
+// RestoreCategory reverses a prior DeleteCategory, restoring the
+// soft-deleted category identified by id.
+func (c *Client) RestoreCategory(id uuid.UUID, authToken string) (*Category, error) {
+	return c.RestoreCategoryContext(context.Background(), id, authToken)
+}
+
+// RestoreCategoryContext is the context-aware, retrying equivalent of
+// RestoreCategory.
+func (c *Client) RestoreCategoryContext(ctx context.Context, id uuid.UUID, authToken string) (*Category, error) {
+	path := fmt.Sprintf("/api/categories/%s/restore", id)
+	var restored Category
+	if _, err := c.doRequest(ctx, http.MethodPost, path, authToken, nil, &restored); err != nil {
+		return nil, err
+	}
+	return &restored, nil
+}
+
+func withIfMatch(version int) RequestOption {
+	return WithHeader("If-Match", strconv.Itoa(version))
+}
+
+// versionConflictErr maps a 409 APIError to ErrVersionConflict, leaving
+// every other error untouched.
+func versionConflictErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		return ErrVersionConflict
+	}
+	return err
+}